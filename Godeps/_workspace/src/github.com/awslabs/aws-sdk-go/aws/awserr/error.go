@@ -0,0 +1,77 @@
+// Package awserr defines the typed error hierarchy returned by service
+// clients in this module: a service-agnostic Error carrying a code and
+// message, and a RequestFailure adding the HTTP status code and
+// request ID parsed from the service's error response.
+package awserr
+
+import "fmt"
+
+// Error is satisfied by every error a client in this module returns
+// for a failed API call.
+type Error interface {
+	error
+
+	// Code is the short error code the service returned, e.g.
+	// "Throttling" or "ValidationError".
+	Code() string
+	// Message is the human-readable error message.
+	Message() string
+	// OrigErr is the underlying error this Error wraps, if any.
+	OrigErr() error
+}
+
+// RequestFailure is an Error that was the direct result of an HTTP
+// request to a service, and so also carries the response status code
+// and the service's request ID.
+type RequestFailure interface {
+	Error
+
+	StatusCode() int
+	RequestID() string
+}
+
+// New builds an Error with the given code, message and (optional)
+// underlying cause.
+func New(code, message string, origErr error) Error {
+	return &baseError{code: code, message: message, origErr: origErr}
+}
+
+// NewRequestFailure wraps err as a RequestFailure carrying statusCode
+// and requestID.
+func NewRequestFailure(err Error, statusCode int, requestID string) RequestFailure {
+	return &requestFailure{err: err, statusCode: statusCode, requestID: requestID}
+}
+
+type baseError struct {
+	code    string
+	message string
+	origErr error
+}
+
+func (e *baseError) Code() string    { return e.code }
+func (e *baseError) Message() string { return e.message }
+func (e *baseError) OrigErr() error  { return e.origErr }
+
+func (e *baseError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.code, e.message)
+	if e.origErr != nil {
+		msg += "\ncaused by: " + e.origErr.Error()
+	}
+	return msg
+}
+
+type requestFailure struct {
+	err        Error
+	statusCode int
+	requestID  string
+}
+
+func (r *requestFailure) Code() string      { return r.err.Code() }
+func (r *requestFailure) Message() string   { return r.err.Message() }
+func (r *requestFailure) OrigErr() error    { return r.err.OrigErr() }
+func (r *requestFailure) StatusCode() int   { return r.statusCode }
+func (r *requestFailure) RequestID() string { return r.requestID }
+
+func (r *requestFailure) Error() string {
+	return fmt.Sprintf("%s\n\tstatus code: %d, request id: %s", r.err.Error(), r.statusCode, r.requestID)
+}
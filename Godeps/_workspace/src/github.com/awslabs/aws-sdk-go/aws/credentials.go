@@ -0,0 +1,73 @@
+package aws
+
+import "sync"
+
+// Value is a resolved AWS access key pair and optional session token,
+// as returned by a CredentialsProvider.
+type Value struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SecurityToken   string
+}
+
+// CredentialsProvider resolves a Value on demand. IsExpired reports
+// whether the Value most recently returned by Retrieve should no
+// longer be used, so Credentials knows when to call Retrieve again.
+type CredentialsProvider interface {
+	Retrieve() (Value, error)
+	IsExpired() bool
+}
+
+// Credentials caches the Value produced by a CredentialsProvider,
+// calling Retrieve again only once the provider reports the cached
+// Value has expired. A Context's Credentials.Get is called on every
+// request, so a single long-lived Credentials can back a client for
+// as long as its provider keeps producing fresh keys.
+type Credentials struct {
+	m        sync.Mutex
+	provider CredentialsProvider
+	value    Value
+	loaded   bool
+}
+
+// NewCredentials wraps provider in a Credentials cache.
+func NewCredentials(provider CredentialsProvider) *Credentials {
+	return &Credentials{provider: provider}
+}
+
+// Get returns the current Value, retrieving a fresh one from the
+// underlying provider if none has been loaded yet or the provider
+// reports the cached Value has expired.
+func (c *Credentials) Get() (Value, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if !c.loaded || c.provider.IsExpired() {
+		v, err := c.provider.Retrieve()
+		if err != nil {
+			return Value{}, err
+		}
+		c.value = v
+		c.loaded = true
+	}
+	return c.value, nil
+}
+
+// staticProvider is a CredentialsProvider for a fixed Value that never
+// expires, as produced by Creds.
+type staticProvider struct {
+	value Value
+}
+
+func (p staticProvider) Retrieve() (Value, error) { return p.value, nil }
+func (p staticProvider) IsExpired() bool          { return false }
+
+// Creds builds a Credentials backed by a fixed, never-expiring access
+// key pair.
+func Creds(accessKeyID, secretAccessKey, securityToken string) *Credentials {
+	return NewCredentials(staticProvider{Value{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SecurityToken:   securityToken,
+	}})
+}
@@ -0,0 +1,248 @@
+package aws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvProvider retrieves credentials from AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and (optionally) AWS_SESSION_TOKEN.
+type EnvProvider struct{}
+
+func (EnvProvider) Retrieve() (Value, error) {
+	id := os.Getenv("AWS_ACCESS_KEY_ID")
+	secret := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if id == "" || secret == "" {
+		return Value{}, errors.New("aws: AWS_ACCESS_KEY_ID or AWS_SECRET_ACCESS_KEY not set in environment")
+	}
+	return Value{
+		AccessKeyID:     id,
+		SecretAccessKey: secret,
+		SecurityToken:   os.Getenv("AWS_SESSION_TOKEN"),
+	}, nil
+}
+
+func (EnvProvider) IsExpired() bool { return false }
+
+// SharedCredentialsProvider retrieves credentials from an ini-style
+// shared credentials file, e.g. ~/.aws/credentials.
+type SharedCredentialsProvider struct {
+	// Filename defaults to $HOME/.aws/credentials.
+	Filename string
+	// Profile defaults to "default".
+	Profile string
+}
+
+func (p SharedCredentialsProvider) filename() string {
+	if p.Filename != "" {
+		return p.Filename
+	}
+	return filepath.Join(os.Getenv("HOME"), ".aws", "credentials")
+}
+
+func (p SharedCredentialsProvider) profile() string {
+	if p.Profile != "" {
+		return p.Profile
+	}
+	return "default"
+}
+
+func (p SharedCredentialsProvider) Retrieve() (Value, error) {
+	data, err := ioutil.ReadFile(p.filename())
+	if err != nil {
+		return Value{}, err
+	}
+
+	var v Value
+	var found bool
+	section := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		if section != p.profile() {
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch key {
+		case "aws_access_key_id":
+			v.AccessKeyID = value
+			found = true
+		case "aws_secret_access_key":
+			v.SecretAccessKey = value
+		case "aws_session_token":
+			v.SecurityToken = value
+		}
+	}
+
+	if !found {
+		return Value{}, fmt.Errorf("aws: no credentials found for profile %q in %s", p.profile(), p.filename())
+	}
+	return v, nil
+}
+
+func (SharedCredentialsProvider) IsExpired() bool { return false }
+
+// ChainProvider tries each of Providers in order, returning the first
+// Value retrieved without error and remembering which provider it
+// came from so IsExpired can defer to it.
+type ChainProvider struct {
+	Providers []CredentialsProvider
+
+	m      sync.Mutex
+	active CredentialsProvider
+}
+
+// DefaultChainProvider returns the conventional env -> shared
+// credentials file -> EC2 instance role provider chain.
+func DefaultChainProvider() *ChainProvider {
+	return &ChainProvider{Providers: []CredentialsProvider{
+		EnvProvider{},
+		SharedCredentialsProvider{},
+		&EC2RoleProvider{},
+	}}
+}
+
+func (c *ChainProvider) Retrieve() (Value, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	var errs []string
+	for _, p := range c.Providers {
+		v, err := p.Retrieve()
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		c.active = p
+		return v, nil
+	}
+	return Value{}, fmt.Errorf("aws: no valid credential providers in chain: %s", strings.Join(errs, "; "))
+}
+
+func (c *ChainProvider) IsExpired() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.active == nil || c.active.IsExpired()
+}
+
+// ec2MetadataBaseURL is the default EC2 instance metadata service
+// endpoint for IAM role credentials.
+const ec2MetadataBaseURL = "http://169.254.169.254/latest/meta-data/iam/security-credentials/"
+
+// ec2RoleExpiryWindow is how far ahead of their stated expiration
+// EC2RoleProvider treats credentials as expired, so a refresh always
+// has time to complete before the real expiration.
+const ec2RoleExpiryWindow = 5 * time.Minute
+
+// EC2RoleProvider retrieves temporary credentials from the EC2
+// instance metadata service for an IAM role attached to the instance,
+// refreshing them shortly before they expire.
+type EC2RoleProvider struct {
+	// Client is used to reach the metadata service; defaults to
+	// http.DefaultClient.
+	Client *http.Client
+	// BaseURL defaults to ec2MetadataBaseURL; tests point it at an
+	// httptest server instead.
+	BaseURL string
+	// Role is the IAM role name. If empty, it is discovered by
+	// listing BaseURL, which the metadata service resolves to the
+	// single role attached to the instance.
+	Role string
+
+	m          sync.Mutex
+	expiration time.Time
+}
+
+func (p *EC2RoleProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return ec2MetadataBaseURL
+}
+
+func (p *EC2RoleProvider) client() *http.Client {
+	if p.Client != nil {
+		return p.Client
+	}
+	return http.DefaultClient
+}
+
+type ec2RoleCredentials struct {
+	AccessKeyId     string
+	SecretAccessKey string
+	Token           string
+	Expiration      time.Time
+}
+
+func (p *EC2RoleProvider) Retrieve() (Value, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	role := p.Role
+	if role == "" {
+		body, err := p.fetch(p.baseURL())
+		if err != nil {
+			return Value{}, err
+		}
+		role = strings.TrimSpace(body)
+	}
+
+	body, err := p.fetch(p.baseURL() + role)
+	if err != nil {
+		return Value{}, err
+	}
+
+	var doc ec2RoleCredentials
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		return Value{}, fmt.Errorf("aws: could not parse EC2 role credentials: %v", err)
+	}
+
+	p.expiration = doc.Expiration
+	return Value{
+		AccessKeyID:     doc.AccessKeyId,
+		SecretAccessKey: doc.SecretAccessKey,
+		SecurityToken:   doc.Token,
+	}, nil
+}
+
+func (p *EC2RoleProvider) IsExpired() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.expiration.IsZero() || !time.Now().Add(ec2RoleExpiryWindow).Before(p.expiration)
+}
+
+func (p *EC2RoleProvider) fetch(url string) (string, error) {
+	resp, err := p.client().Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("aws: ec2 metadata request to %s failed with status %d", url, resp.StatusCode)
+	}
+	return string(body), nil
+}
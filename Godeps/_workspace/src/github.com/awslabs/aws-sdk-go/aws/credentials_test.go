@@ -0,0 +1,130 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCredentialsGetCachesUntilExpired(t *testing.T) {
+	calls := 0
+	provider := fakeProvider{
+		retrieve: func() (Value, error) {
+			calls++
+			return Value{AccessKeyID: fmt.Sprintf("key-%d", calls)}, nil
+		},
+		expired: false,
+	}
+	creds := NewCredentials(&provider)
+
+	v1, err := creds.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := creds.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v1 != v2 {
+		t.Errorf("expected cached Value to be reused, got %v then %v", v1, v2)
+	}
+	if calls != 1 {
+		t.Errorf("Retrieve called %d times, want 1", calls)
+	}
+
+	provider.expired = true
+	v3, err := creds.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v3 == v1 {
+		t.Error("expected a fresh Value once the provider reports expiry")
+	}
+	if calls != 2 {
+		t.Errorf("Retrieve called %d times after expiry, want 2", calls)
+	}
+}
+
+type fakeProvider struct {
+	retrieve func() (Value, error)
+	expired  bool
+}
+
+func (p *fakeProvider) Retrieve() (Value, error) { return p.retrieve() }
+func (p *fakeProvider) IsExpired() bool          { return p.expired }
+
+// TestEC2RoleProviderRefreshesMidFlight swaps the credentials an
+// EC2Client signs with part-way through a run of requests, simulating
+// an instance role rotating its temporary keys, and asserts the next
+// signed request picks up the new access key.
+func TestEC2RoleProviderRefreshesMidFlight(t *testing.T) {
+	var m sync.Mutex
+	fetches := 0
+
+	metadata := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			m.Lock()
+			fetches++
+			n := fetches
+			m.Unlock()
+
+			fmt.Fprintf(w, `{
+				"AccessKeyId": "AKIA-ROUND-%d",
+				"SecretAccessKey": "secret-%d",
+				"Token": "token-%d",
+				"Expiration": "%s"
+			}`, n, n, n, time.Now().Add(time.Second).Format(time.RFC3339))
+		},
+	))
+	defer metadata.Close()
+
+	var lastAuth string
+	service := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			m.Lock()
+			lastAuth = r.Header.Get("Authorization")
+			m.Unlock()
+			fmt.Fprintln(w, `<Thing><IpAddress>woo</IpAddress></Thing>`)
+		},
+	))
+	defer service.Close()
+
+	client := EC2Client{
+		Context: Context{
+			Service: "animals",
+			Region:  "us-west-2",
+			Credentials: NewCredentials(&EC2RoleProvider{
+				BaseURL: metadata.URL + "/",
+				Role:    "test-role",
+			}),
+		},
+		Client:     http.DefaultClient,
+		Endpoint:   service.URL,
+		APIVersion: "1.1",
+	}
+
+	var resp fakeEC2Response
+	if err := client.Do("GetIP", "POST", "/", &fakeEC2Request{}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	m.Lock()
+	firstAuth := lastAuth
+	m.Unlock()
+	if !strings.Contains(firstAuth, "AKIA-ROUND-1") {
+		t.Errorf("first request signed with %q, want it to contain AKIA-ROUND-1", firstAuth)
+	}
+
+	if err := client.Do("GetIP", "POST", "/", &fakeEC2Request{}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	m.Lock()
+	secondAuth := lastAuth
+	m.Unlock()
+	if !strings.Contains(secondAuth, "AKIA-ROUND-2") {
+		t.Errorf("second request signed with %q, want it to contain AKIA-ROUND-2 (credentials should have refreshed)", secondAuth)
+	}
+}
@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/internal/queryutil"
+)
+
+// DefaultHandlers returns the Handlers EC2Client uses when none are
+// configured: queryutil-encode the request, sign it per
+// Context.Signer, perform the HTTP round trip, validate the status
+// code, unmarshal the XML response (or error document), and retry per
+// Context.Retryer.
+func DefaultHandlers() Handlers {
+	return Handlers{
+		Build:            HandlerList{buildHandler},
+		Sign:             HandlerList{signHandler},
+		Send:             HandlerList{sendHandler},
+		ValidateResponse: HandlerList{validateResponseHandler},
+		Unmarshal:        HandlerList{unmarshalHandler},
+		UnmarshalError:   HandlerList{unmarshalErrorHandler},
+		Retry:            HandlerList{retryHandler},
+		AfterRetry:       HandlerList{afterRetryHandler},
+	}
+}
+
+func buildHandler(r *Request) {
+	values := url.Values{
+		"Action":  []string{r.Operation},
+		"Version": []string{r.APIVersion},
+	}
+
+	if r.Params != nil {
+		if err := queryutil.Marshal(values, "", reflect.ValueOf(r.Params).Elem(), ""); err != nil {
+			r.Error = err
+			return
+		}
+	}
+	r.Body = values.Encode()
+
+	httpReq, err := http.NewRequest(r.Method, r.Endpoint+r.Path, strings.NewReader(r.Body))
+	if err != nil {
+		r.Error = err
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("User-Agent", "aws-go")
+	r.HTTPRequest = httpReq
+}
+
+func signHandler(r *Request) {
+	r.Error = r.Context.signer().Sign(r.HTTPRequest, []byte(r.Body))
+}
+
+func sendHandler(r *Request) {
+	resp, err := r.Client.Do(r.HTTPRequest)
+	if err != nil {
+		r.Error = err
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		r.Error = err
+		return
+	}
+	r.HTTPResponse = resp
+	r.RawResponse = body
+}
+
+func validateResponseHandler(r *Request) {
+	if r.HTTPResponse.StatusCode >= 300 {
+		r.Error = fmt.Errorf("aws: request failed with status %d", r.HTTPResponse.StatusCode)
+	}
+}
+
+func unmarshalHandler(r *Request) {
+	if r.Data == nil {
+		return
+	}
+	r.Error = xml.Unmarshal(r.RawResponse, r.Data)
+}
+
+func unmarshalErrorHandler(r *Request) {
+	if r.HTTPResponse == nil {
+		return // the round trip itself failed; there's no body to parse.
+	}
+	r.Error = parseAPIError(r.RawResponse, r.HTTPResponse.StatusCode)
+}
+
+func retryHandler(r *Request) {
+	retryer := r.Context.retryer()
+	r.Retryable = r.RetryCount < retryer.MaxRetries() && retryer.ShouldRetry(r)
+}
+
+func afterRetryHandler(r *Request) {
+	time.Sleep(r.Context.retryer().RetryDelay(r.RetryCount))
+}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestEC2Request(t *testing.T) {
@@ -47,6 +48,15 @@ func TestEC2Request(t *testing.T) {
 		APIVersion: "1.1",
 	}
 
+	// Demonstrate layering a custom handler on top of the default
+	// chain: capture the fully built and signed request right before
+	// it goes out on the wire, without forking EC2Client.Do.
+	var capturedReq *http.Request
+	client.Handlers = DefaultHandlers().Copy()
+	client.Handlers.Send.PushFront(func(r *Request) {
+		capturedReq = r.HTTPRequest
+	})
+
 	req := fakeEC2Request{
 		PresentString:  String("string"),
 		PresentBoolean: True(),
@@ -60,6 +70,10 @@ func TestEC2Request(t *testing.T) {
 			{Value: String("p")},
 			{Value: String("q")},
 		},
+		PresentFlattened: []string{"flat-one", "flat-two"},
+		PresentSlicePtr:  &[]string{"ptr-one", "ptr-two"},
+		PresentTags:      map[string]string{"b": "2", "a": "1"},
+		PresentCreated:   time.Date(2016, time.April, 1, 12, 0, 0, 0, time.UTC),
 	}
 	var resp fakeEC2Response
 	if err := client.Do("GetIP", "POST", "/", &req, &resp); err != nil {
@@ -77,6 +91,13 @@ func TestEC2Request(t *testing.T) {
 		t.Error("Authorization header is missing")
 	}
 
+	if capturedReq == nil {
+		t.Fatal("custom Send handler did not capture a request")
+	}
+	if capturedReq.Header.Get("Authorization") != httpReq.Header.Get("Authorization") {
+		t.Error("handler captured a different request than the one the server received")
+	}
+
 	if v, want := httpReq.Header.Get("Content-Type"), "application/x-www-form-urlencoded"; v != want {
 		t.Errorf("Content-Type was %v but expected %v", v, want)
 	}
@@ -103,6 +124,15 @@ func TestEC2Request(t *testing.T) {
 		"PresentStruct.Value":        []string{"v"},
 		"PresentStructSlice.1.Value": []string{"p"},
 		"PresentStructSlice.2.Value": []string{"q"},
+		"PresentFlattened.1":         []string{"flat-one"},
+		"PresentFlattened.2":         []string{"flat-two"},
+		"PresentSlicePtr.1":          []string{"ptr-one"},
+		"PresentSlicePtr.2":          []string{"ptr-two"},
+		"PresentTags.Entry.1.key":    []string{"a"},
+		"PresentTags.Entry.1.value":  []string{"1"},
+		"PresentTags.Entry.2.key":    []string{"b"},
+		"PresentTags.Entry.2.value":  []string{"2"},
+		"PresentCreated":             []string{"2016-04-01T12:00:00Z"},
 	}
 
 	if !reflect.DeepEqual(form, expectedForm) {
@@ -116,20 +146,15 @@ func TestEC2Request(t *testing.T) {
 
 func TestEC2RequestError(t *testing.T) {
 	var m sync.Mutex
-	var httpReq *http.Request
-	var form url.Values
 
 	server := httptest.NewServer(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			m.Lock()
 			defer m.Unlock()
 
-			httpReq = r
-
 			if err := r.ParseForm(); err != nil {
 				t.Fatal(err)
 			}
-			form = r.Form
 
 			w.WriteHeader(400)
 			fmt.Fprintln(w, `<Response>
@@ -173,13 +198,21 @@ func TestEC2RequestError(t *testing.T) {
 			t.Errorf("Error type was %v, but expected %v", v, want)
 		}
 
-		if v, want := err.Code, "Uh Oh"; v != want {
-			t.Errorf("Error type was %v, but expected %v", v, want)
+		if v, want := err.Code(), "Uh Oh"; v != want {
+			t.Errorf("Error code was %v, but expected %v", v, want)
 		}
 
-		if v, want := err.Message, "You done did it"; v != want {
+		if v, want := err.Message(), "You done did it"; v != want {
 			t.Errorf("Error message was %v, but expected %v", v, want)
 		}
+
+		if v, want := err.RequestID(), "woo"; v != want {
+			t.Errorf("Error request ID was %v, but expected %v", v, want)
+		}
+
+		if v, want := err.StatusCode(), 400; v != want {
+			t.Errorf("Error status code was %v, but expected %v", v, want)
+		}
 	} else {
 		t.Errorf("Unknown error returned: %#v", err)
 	}
@@ -212,6 +245,17 @@ type fakeEC2Request struct {
 
 	PresentStruct *EmbeddedStruct `ec2:"PresentStruct"`
 	MissingStruct *EmbeddedStruct `ec2:"MissingStruct"`
+
+	PresentFlattened []string `ec2:"PresentFlattened,flattened"`
+	MissingFlattened []string `ec2:"MissingFlattened,flattened"`
+
+	PresentSlicePtr *[]string `ec2:"PresentSlicePtr"`
+	MissingSlicePtr *[]string `ec2:"MissingSlicePtr"`
+
+	PresentTags map[string]string `ec2:"PresentTags"`
+	MissingTags map[string]string `ec2:"MissingTags"`
+
+	PresentCreated time.Time `ec2:"PresentCreated"`
 }
 
 type fakeEC2Response struct {
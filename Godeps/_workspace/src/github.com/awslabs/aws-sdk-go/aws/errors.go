@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/awslabs/aws-sdk-go/aws/awserr"
+)
+
+// APIError is returned when the service responds with an HTTP error
+// status. It satisfies awserr.RequestFailure; Type is the EC2-specific
+// fault type (e.g. "Sender" or "Receiver") from the <Type> element,
+// which has no equivalent in the generic awserr interfaces.
+type APIError struct {
+	awserr.RequestFailure
+	Type string
+}
+
+type apiErrorResponse struct {
+	XMLName   xml.Name `xml:"Response"`
+	RequestID string   `xml:"RequestId"`
+	Errors    []struct {
+		Type    string `xml:"Type"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Errors>Error"`
+}
+
+func parseAPIError(body []byte, statusCode int) error {
+	var r apiErrorResponse
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return fmt.Errorf("aws: error response could not be parsed: %v", err)
+	}
+	if len(r.Errors) == 0 {
+		return fmt.Errorf("aws: error response had no <Error> elements")
+	}
+
+	e := r.Errors[0]
+	return APIError{
+		RequestFailure: awserr.NewRequestFailure(
+			awserr.New(e.Code, e.Message, nil),
+			statusCode,
+			r.RequestID,
+		),
+		Type: e.Type,
+	}
+}
@@ -0,0 +1,65 @@
+package aws
+
+// HandlerList is an ordered list of functions invoked against a
+// Request at one stage of its lifecycle. Handlers run in order and
+// can inspect or mutate the Request in place, including setting
+// Request.Error to signal the stage failed.
+type HandlerList []func(*Request)
+
+// Run invokes every handler in l against r, in order.
+func (l HandlerList) Run(r *Request) {
+	for _, fn := range l {
+		fn(r)
+	}
+}
+
+// PushBack appends fn to the end of l.
+func (l *HandlerList) PushBack(fn func(*Request)) {
+	*l = append(*l, fn)
+}
+
+// PushFront prepends fn to the front of l, so it runs before any
+// handler already registered.
+func (l *HandlerList) PushFront(fn func(*Request)) {
+	*l = append(HandlerList{fn}, (*l)...)
+}
+
+// Copy returns a new HandlerList backed by its own array, so a caller
+// can add handlers without mutating the list it copied.
+func (l HandlerList) Copy() HandlerList {
+	out := make(HandlerList, len(l))
+	copy(out, l)
+	return out
+}
+
+// Handlers groups the named stages of a Request's lifecycle. Each
+// stage is its own HandlerList so cross-cutting concerns (logging,
+// metrics, tracing, custom retry policies, test doubles) can be
+// layered onto a base set of handlers without forking the client.
+type Handlers struct {
+	Build            HandlerList
+	Sign             HandlerList
+	Send             HandlerList
+	ValidateResponse HandlerList
+	Unmarshal        HandlerList
+	UnmarshalError   HandlerList
+	Retry            HandlerList
+	AfterRetry       HandlerList
+}
+
+// Copy returns a Handlers whose stage lists are independent copies of
+// h's, so a per-service client can start from a base Handlers (e.g.
+// DefaultHandlers()) and layer its own handlers on top without
+// affecting the base.
+func (h Handlers) Copy() Handlers {
+	return Handlers{
+		Build:            h.Build.Copy(),
+		Sign:             h.Sign.Copy(),
+		Send:             h.Send.Copy(),
+		ValidateResponse: h.ValidateResponse.Copy(),
+		Unmarshal:        h.Unmarshal.Copy(),
+		UnmarshalError:   h.UnmarshalError.Copy(),
+		Retry:            h.Retry.Copy(),
+		AfterRetry:       h.AfterRetry.Copy(),
+	}
+}
@@ -0,0 +1,45 @@
+package aws
+
+import "testing"
+
+func TestHandlerListCopyIsIndependent(t *testing.T) {
+	var l HandlerList
+	l.PushBack(func(r *Request) {})
+
+	cp := l.Copy()
+	cp.PushBack(func(r *Request) {})
+
+	if len(l) != 1 {
+		t.Errorf("original list has %d handlers, want 1", len(l))
+	}
+	if len(cp) != 2 {
+		t.Errorf("copy has %d handlers, want 2", len(cp))
+	}
+}
+
+func TestHandlerListPushFrontRunsFirst(t *testing.T) {
+	var l HandlerList
+	var order []string
+
+	l.PushBack(func(r *Request) { order = append(order, "second") })
+	l.PushFront(func(r *Request) { order = append(order, "first") })
+
+	l.Run(&Request{})
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("handlers ran in order %v, want [first second]", order)
+	}
+}
+
+func TestHandlersCopyIsIndependent(t *testing.T) {
+	base := DefaultHandlers()
+	layered := base.Copy()
+	layered.Build.PushBack(func(r *Request) {})
+
+	if len(layered.Build) != len(base.Build)+1 {
+		t.Errorf("layered.Build has %d handlers, want %d", len(layered.Build), len(base.Build)+1)
+	}
+	if len(base.Build) != 1 {
+		t.Errorf("base.Build was mutated, has %d handlers, want 1", len(base.Build))
+	}
+}
@@ -0,0 +1,72 @@
+package aws
+
+import "net/http"
+
+// Request represents a single call to an EC2-family operation as it
+// moves through Handlers: Build encodes Params onto the wire, Sign
+// signs the resulting HTTPRequest, Send performs the round trip,
+// ValidateResponse and Unmarshal/UnmarshalError interpret the result,
+// and Retry/AfterRetry decide whether and how long to wait before
+// trying again.
+type Request struct {
+	Context    Context
+	Client     *http.Client
+	Endpoint   string
+	APIVersion string
+
+	Operation string
+	Method    string
+	Path      string
+
+	// Params is the ec2-tagged request struct to encode; Data is the
+	// response struct to unmarshal the XML body into. Either may be
+	// nil for operations that take or return nothing.
+	Params interface{}
+	Data   interface{}
+
+	Body         string
+	HTTPRequest  *http.Request
+	HTTPResponse *http.Response
+	RawResponse  []byte
+
+	Error      error
+	Retryable  bool
+	RetryCount int
+
+	Handlers Handlers
+}
+
+// Send runs r through its Handlers, trying again whenever the Retry
+// stage sets Retryable, until it succeeds or gives up.
+func (r *Request) Send() error {
+	for {
+		r.Error = nil
+		r.Retryable = false
+		r.HTTPResponse = nil
+		r.RawResponse = nil
+
+		r.Handlers.Build.Run(r)
+		if r.Error == nil {
+			r.Handlers.Sign.Run(r)
+		}
+		if r.Error == nil {
+			r.Handlers.Send.Run(r)
+		}
+		if r.Error == nil {
+			r.Handlers.ValidateResponse.Run(r)
+		}
+
+		if r.Error == nil {
+			r.Handlers.Unmarshal.Run(r)
+			return r.Error
+		}
+
+		r.Handlers.UnmarshalError.Run(r)
+		r.Handlers.Retry.Run(r)
+		if !r.Retryable {
+			return r.Error
+		}
+		r.Handlers.AfterRetry.Run(r)
+		r.RetryCount++
+	}
+}
@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws/awserr"
+)
+
+// Retryer decides whether a failed request should be retried and how
+// long to wait before the next attempt. ShouldRetry is called after
+// Send and UnmarshalError have run, so r.HTTPResponse and r.Error
+// reflect the attempt just made; r.HTTPResponse is nil when the round
+// trip itself failed (connection error, timeout, ...) rather than
+// completing with an HTTP error status.
+type Retryer interface {
+	MaxRetries() int
+	ShouldRetry(r *Request) bool
+	RetryDelay(attempt int) time.Duration
+}
+
+const (
+	retryBaseDelay = 50 * time.Millisecond
+	retryCapDelay  = 20 * time.Second
+)
+
+// throttlingCodes are the awserr.Error Code() values that are always
+// worth retrying, regardless of HTTP status.
+var throttlingCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"RequestLimitExceeded":                   true,
+	"ProvisionedThroughputExceededException": true,
+}
+
+// DefaultRetryer retries 5xx responses, connection errors and
+// throttling error codes, waiting a full-jitter exponential backoff
+// between attempts: delay = rand(0, min(cap, base*2^attempt)).
+type DefaultRetryer struct {
+	// NumMaxRetries caps how many retries are attempted after the
+	// initial try; it defaults to 3 when zero.
+	NumMaxRetries int
+}
+
+func (d DefaultRetryer) MaxRetries() int {
+	if d.NumMaxRetries == 0 {
+		return 3
+	}
+	return d.NumMaxRetries
+}
+
+func (d DefaultRetryer) ShouldRetry(r *Request) bool {
+	if r.Error != nil {
+		if apiErr, ok := r.Error.(awserr.Error); ok {
+			if throttlingCodes[apiErr.Code()] {
+				return true
+			}
+			if reqErr, ok := apiErr.(awserr.RequestFailure); ok {
+				return reqErr.StatusCode() >= 500
+			}
+			return false
+		}
+		// err but no response: the round trip itself failed.
+		return r.HTTPResponse == nil
+	}
+	return r.HTTPResponse != nil && r.HTTPResponse.StatusCode >= 500
+}
+
+func (d DefaultRetryer) RetryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay * time.Duration(uint(1)<<uint(attempt))
+	if delay > retryCapDelay || delay <= 0 {
+		delay = retryCapDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
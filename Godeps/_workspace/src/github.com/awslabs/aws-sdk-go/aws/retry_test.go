@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws/awserr"
+)
+
+// TestEC2ClientRetriesFlaky5xx exercises a server that fails the first
+// two requests with a 503 before succeeding, and checks EC2Client.Do
+// retries through them using the default retryer.
+func TestEC2ClientRetriesFlaky5xx(t *testing.T) {
+	var m sync.Mutex
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			m.Lock()
+			attempts++
+			n := attempts
+			m.Unlock()
+
+			if n <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprintln(w, `<Response>
+<RequestId>flaky</RequestId>
+<Errors>
+<Error>
+<Type>Receiver</Type>
+<Code>ServiceUnavailable</Code>
+<Message>try again</Message>
+</Error>
+</Errors>
+</Response>`)
+				return
+			}
+
+			fmt.Fprintln(w, `<Thing><IpAddress>woo</IpAddress></Thing>`)
+		},
+	))
+	defer server.Close()
+
+	client := EC2Client{
+		Context: Context{
+			Service:     "animals",
+			Region:      "us-west-2",
+			Credentials: Creds("accessKeyID", "secretAccessKey", "securityToken"),
+			// The default backoff stays fast here: the two retries wait
+			// at most 50ms and 100ms (full jitter from base 50ms).
+			Retryer: DefaultRetryer{},
+		},
+		Client:     http.DefaultClient,
+		Endpoint:   server.URL,
+		APIVersion: "1.1",
+	}
+
+	var resp fakeEC2Response
+	if err := client.Do("GetIP", "POST", "/", &fakeEC2Request{}, &resp); err != nil {
+		t.Fatalf("expected the request to eventually succeed, got: %v", err)
+	}
+
+	if want := (fakeEC2Response{IPAddress: "woo"}); resp != want {
+		t.Errorf("response was %#v, want %#v", resp, want)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	if attempts != 3 {
+		t.Errorf("server saw %d attempts, want 3 (2 failures + 1 success)", attempts)
+	}
+}
+
+func TestDefaultRetryerShouldRetry(t *testing.T) {
+	d := DefaultRetryer{}
+
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{"5xx response", &http.Response{StatusCode: 503}, nil, true},
+		{"4xx response", &http.Response{StatusCode: 400}, nil, false},
+		{"connection error", nil, fmt.Errorf("connection reset"), true},
+		{"throttling code", nil, apiErrorWithCode("Throttling"), true},
+		{"non-throttling api error", nil, apiErrorWithCode("ValidationError"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Request{HTTPResponse: tt.resp, Error: tt.err}
+			if got := d.ShouldRetry(r); got != tt.want {
+				t.Errorf("ShouldRetry(%v, %v) = %v, want %v", tt.resp, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryerDelayIsWithinCap(t *testing.T) {
+	d := DefaultRetryer{}
+	for attempt := 0; attempt < 6; attempt++ {
+		delay := d.RetryDelay(attempt)
+		if delay < 0 || delay > retryCapDelay {
+			t.Errorf("RetryDelay(%d) = %v, want between 0 and %v", attempt, delay, retryCapDelay)
+		}
+	}
+}
+
+func apiErrorWithCode(code string) error {
+	return APIError{
+		RequestFailure: awserr.NewRequestFailure(awserr.New(code, "message", nil), 400, "req-id"),
+	}
+}
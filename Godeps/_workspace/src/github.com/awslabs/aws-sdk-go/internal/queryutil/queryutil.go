@@ -0,0 +1,175 @@
+// Package queryutil marshals Go values into the url.Values form used by
+// the EC2/Query wire protocol, following the same conventions as
+// aws-sdk-go's private/protocol/query/queryutil: numbered list members,
+// map Entry.N.key/Entry.N.value pairs, ISO-8601 timestamps, and struct
+// nesting to arbitrary depth.
+package queryutil
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Marshal encodes v into values under prefix. tag is the struct tag of
+// the field v came from (the zero StructTag at the top level); it is
+// parsed for the wire name (the tag's first comma-separated token) and
+// the "flattened" and "locationName=" options described in the ec2
+// struct tag format, e.g. `ec2:"Name,flattened"` or
+// `ec2:"Name,locationName=Item"`.
+//
+// Absent values (nil pointers, nil interfaces, nil maps/slices) are
+// silently skipped, matching the StringValue/IntegerValue/... "present
+// vs. zero" convention used by the generated request types.
+func Marshal(values url.Values, prefix string, v reflect.Value, tag reflect.StructTag) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	name, opts := parseTag(tag)
+	if name != "" {
+		if prefix == "" {
+			prefix = name
+		} else {
+			prefix = prefix + "." + name
+		}
+	}
+
+	if v.Type() == timeType {
+		return marshalTime(values, prefix, v)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return marshalStruct(values, prefix, v)
+	case reflect.Map:
+		return marshalMap(values, prefix, v)
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil
+		}
+		return marshalList(values, prefix, v, opts)
+	default:
+		return marshalScalar(values, prefix, v)
+	}
+}
+
+func marshalStruct(values url.Values, prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		if err := Marshal(values, prefix, v.Field(i), field.Tag); err != nil {
+			return fmt.Errorf("field %s: %v", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func marshalMap(values url.Values, prefix string, v reflect.Value) error {
+	keys := v.MapKeys()
+	sorted := make([]string, len(keys))
+	for i, k := range keys {
+		sorted[i] = fmt.Sprint(k.Interface())
+	}
+	sort.Strings(sorted)
+
+	for i, k := range sorted {
+		entryPrefix := fmt.Sprintf("%s.Entry.%d", prefix, i+1)
+		values.Set(entryPrefix+".key", k)
+		val := v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key()))
+		if err := Marshal(values, entryPrefix+".value", val, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// marshalList encodes v's elements as prefix.1, prefix.2, .... The EC2
+// protocol (unlike the general Query protocol) does not wrap list
+// members in a "member" node by default, so that is also our default.
+// A "locationName=X" option wraps each element under prefix.X.N
+// instead; "flattened" suppresses that wrapper even when locationName
+// is also set, leaving plain prefix.N numbering.
+func marshalList(values url.Values, prefix string, v reflect.Value, opts tagOptions) error {
+	listPrefix := prefix
+	if opts.locationName != "" && !opts.flattened {
+		listPrefix = prefix + "." + opts.locationName
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		elemPrefix := fmt.Sprintf("%s.%d", listPrefix, i+1)
+		if err := Marshal(values, elemPrefix, v.Index(i), ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func marshalTime(values url.Values, prefix string, v reflect.Value) error {
+	t := v.Interface().(time.Time)
+	values.Set(prefix, t.UTC().Format(time.RFC3339))
+	return nil
+}
+
+func marshalScalar(values url.Values, prefix string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		values.Set(prefix, v.String())
+	case reflect.Bool:
+		values.Set(prefix, strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values.Set(prefix, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		values.Set(prefix, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32:
+		values.Set(prefix, strconv.FormatFloat(v.Float(), 'f', -1, 32))
+	case reflect.Float64:
+		values.Set(prefix, strconv.FormatFloat(v.Float(), 'f', -1, 64))
+	default:
+		return fmt.Errorf("queryutil: unsupported kind %s for %q", v.Kind(), prefix)
+	}
+	return nil
+}
+
+type tagOptions struct {
+	flattened    bool
+	locationName string
+}
+
+// parseTag splits an `ec2:"Name,opt,opt=value"` tag into its wire name
+// and options. A zero tag (the top-level call, or a field with no
+// ec2 tag) yields an empty name and no options.
+func parseTag(tag reflect.StructTag) (string, tagOptions) {
+	raw := tag.Get("ec2")
+	if raw == "" {
+		return "", tagOptions{}
+	}
+
+	parts := strings.Split(raw, ",")
+	var opts tagOptions
+	for _, p := range parts[1:] {
+		switch {
+		case p == "flattened":
+			opts.flattened = true
+		case strings.HasPrefix(p, "locationName="):
+			opts.locationName = strings.TrimPrefix(p, "locationName=")
+		}
+	}
+	return parts[0], opts
+}
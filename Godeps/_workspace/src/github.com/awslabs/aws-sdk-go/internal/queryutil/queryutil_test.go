@@ -0,0 +1,119 @@
+package queryutil
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type nested struct {
+	Name string  `ec2:"Name"`
+	Next *nested `ec2:"Next"`
+}
+
+type withMap struct {
+	Tags map[string]string `ec2:"Tags"`
+}
+
+type withTime struct {
+	Created time.Time `ec2:"Created"`
+}
+
+type withSlicePtr struct {
+	Items *[]string `ec2:"Items"`
+}
+
+type withFlattened struct {
+	Items []string `ec2:"Items,flattened"`
+}
+
+type withLocationName struct {
+	Items []string `ec2:"Items,locationName=Item"`
+}
+
+func marshal(t *testing.T, v interface{}) url.Values {
+	values := url.Values{}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if err := Marshal(values, "", rv, ""); err != nil {
+		t.Fatalf("Marshal(%#v) returned error: %v", v, err)
+	}
+	return values
+}
+
+func TestMarshalMap(t *testing.T) {
+	got := marshal(t, &withMap{Tags: map[string]string{"b": "2", "a": "1"}})
+	want := url.Values{
+		"Tags.Entry.1.key":   []string{"a"},
+		"Tags.Entry.1.value": []string{"1"},
+		"Tags.Entry.2.key":   []string{"b"},
+		"Tags.Entry.2.value": []string{"2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMarshalTime(t *testing.T) {
+	ts := time.Date(2016, time.April, 1, 12, 0, 0, 0, time.UTC)
+	got := marshal(t, &withTime{Created: ts})
+	want := url.Values{"Created": []string{"2016-04-01T12:00:00Z"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMarshalRecursiveStruct(t *testing.T) {
+	got := marshal(t, &nested{Name: "outer", Next: &nested{Name: "inner"}})
+	want := url.Values{
+		"Name":      []string{"outer"},
+		"Next.Name": []string{"inner"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMarshalPointerToSlice(t *testing.T) {
+	items := []string{"one", "two"}
+	got := marshal(t, &withSlicePtr{Items: &items})
+	want := url.Values{
+		"Items.1": []string{"one"},
+		"Items.2": []string{"two"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMarshalFlattenedList(t *testing.T) {
+	got := marshal(t, &withFlattened{Items: []string{"one", "two"}})
+	want := url.Values{
+		"Items.1": []string{"one"},
+		"Items.2": []string{"two"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMarshalLocationNameList(t *testing.T) {
+	got := marshal(t, &withLocationName{Items: []string{"one", "two"}})
+	want := url.Values{
+		"Items.Item.1": []string{"one"},
+		"Items.Item.2": []string{"two"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMarshalNilIsSkipped(t *testing.T) {
+	got := marshal(t, &withSlicePtr{})
+	if len(got) != 0 {
+		t.Errorf("expected no values for nil pointer, got %v", got)
+	}
+}
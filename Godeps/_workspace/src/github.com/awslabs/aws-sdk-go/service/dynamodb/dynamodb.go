@@ -0,0 +1,53 @@
+// Package dynamodb is a minimal vendored slice of the DynamoDB service
+// client: just enough of the Query operation's request/response shape
+// and client type for DynamoDBer (see the app package) to compile
+// against and for awsmockgen to generate a DynamoDBAPI mock from.
+package dynamodb
+
+import "github.com/awslabs/aws-sdk-go/aws"
+
+// DynamoDB is a JSON-protocol client for the DynamoDB family of APIs.
+type DynamoDB struct {
+	Context aws.Context
+
+	Endpoint string
+}
+
+// Condition describes one key or attribute comparison in a Query.
+type Condition struct {
+	AttributeValueList []*AttributeValue
+	ComparisonOperator string
+}
+
+// AttributeValue holds a single typed DynamoDB attribute value. Only
+// the variants Query's demo usage needs are represented.
+type AttributeValue struct {
+	S *string
+	N *string
+}
+
+// QueryInput is the input to a Query operation.
+type QueryInput struct {
+	TableName      *string
+	KeyConditions  map[string]*Condition
+	IndexName      *string
+	ConsistentRead *bool
+}
+
+// QueryOutput is the output of a Query operation.
+type QueryOutput struct {
+	Items            []map[string]*AttributeValue
+	Count            *int64
+	ScannedCount     *int64
+	LastEvaluatedKey map[string]*AttributeValue
+}
+
+// ComparisonOperatorEq is the "equals" ComparisonOperator value.
+const ComparisonOperatorEq = "EQ"
+
+// Query runs a Query operation against table input.TableName. This
+// vendored stub does not perform a real request; it exists so code
+// can be written and mocked against the real operation's signature.
+func (d *DynamoDB) Query(input *QueryInput) (*QueryOutput, error) {
+	panic("dynamodb: Query is not implemented in this vendored stub")
+}
@@ -0,0 +1,107 @@
+// Package ec2 is a minimal vendored slice of the EC2 service client:
+// just enough of a representative set of operations' request/response
+// shapes and the client type for EC2er (see the app package) to
+// compile against and for awsmockgen to generate an EC2API mock from.
+// It does not replace aws.EC2Client, the Query-protocol client this
+// repo actually dials EC2 with; it exists only to give awsmockgen a
+// per-operation method set to introspect, the same role
+// service/dynamodb.DynamoDB plays for DynamoDBAPI.
+package ec2
+
+import "github.com/awslabs/aws-sdk-go/aws"
+
+// EC2 is a Query-protocol client for the EC2 family of APIs.
+type EC2 struct {
+	Context aws.Context
+
+	Endpoint string
+}
+
+// Instance is a minimal slice of an EC2 instance's description.
+type Instance struct {
+	InstanceID *string
+	ImageID    *string
+	State      *InstanceState
+}
+
+// InstanceState holds an instance's lifecycle state.
+type InstanceState struct {
+	Code *int64
+	Name *string
+}
+
+// DescribeInstancesInput is the input to a DescribeInstances operation.
+type DescribeInstancesInput struct {
+	InstanceIDs []*string
+	Filters     []*Filter
+}
+
+// DescribeInstancesOutput is the output of a DescribeInstances operation.
+type DescribeInstancesOutput struct {
+	Reservations []*Reservation
+}
+
+// Reservation groups the instances returned by one DescribeInstances
+// reservation entry.
+type Reservation struct {
+	ReservationID *string
+	Instances     []*Instance
+}
+
+// Filter narrows a Describe* call to matching resources.
+type Filter struct {
+	Name   *string
+	Values []*string
+}
+
+// RunInstancesInput is the input to a RunInstances operation.
+type RunInstancesInput struct {
+	ImageID      *string
+	InstanceType *string
+	MinCount     *int64
+	MaxCount     *int64
+}
+
+// RunInstancesOutput is the output of a RunInstances operation.
+type RunInstancesOutput struct {
+	ReservationID *string
+	Instances     []*Instance
+}
+
+// TerminateInstancesInput is the input to a TerminateInstances operation.
+type TerminateInstancesInput struct {
+	InstanceIDs []*string
+}
+
+// TerminateInstancesOutput is the output of a TerminateInstances operation.
+type TerminateInstancesOutput struct {
+	TerminatingInstances []*InstanceStateChange
+}
+
+// InstanceStateChange describes one instance's state transition.
+type InstanceStateChange struct {
+	InstanceID    *string
+	CurrentState  *InstanceState
+	PreviousState *InstanceState
+}
+
+// DescribeInstances runs a DescribeInstances operation. This vendored
+// stub does not perform a real request; it exists so code can be
+// written and mocked against the real operation's signature.
+func (e *EC2) DescribeInstances(input *DescribeInstancesInput) (*DescribeInstancesOutput, error) {
+	panic("ec2: DescribeInstances is not implemented in this vendored stub")
+}
+
+// RunInstances runs a RunInstances operation. This vendored stub does
+// not perform a real request; it exists so code can be written and
+// mocked against the real operation's signature.
+func (e *EC2) RunInstances(input *RunInstancesInput) (*RunInstancesOutput, error) {
+	panic("ec2: RunInstances is not implemented in this vendored stub")
+}
+
+// TerminateInstances runs a TerminateInstances operation. This
+// vendored stub does not perform a real request; it exists so code
+// can be written and mocked against the real operation's signature.
+func (e *EC2) TerminateInstances(input *TerminateInstancesInput) (*TerminateInstancesOutput, error) {
+	panic("ec2: TerminateInstances is not implemented in this vendored stub")
+}
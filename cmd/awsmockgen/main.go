@@ -0,0 +1,146 @@
+// Command awsmockgen generates a mockable interface and a small
+// testify-style mock for an AWS-style service client: one interface
+// method per operation, plus a mock struct with per-method call
+// recording and stubbed return values, so tests can write
+//
+//	m := &mocks.DynamoDBMock{}
+//	m.On("Query", input).Return(output, nil)
+//
+// instead of standing up an httptest server like TestEC2Request does.
+// It is driven by go:generate directives, e.g.:
+//
+//	//go:generate go run ./cmd/awsmockgen -pkg mocks -type DynamoDBAPI -mock DynamoDBMock -out mocks/dynamodbapi_generated.go -import github.com/awslabs/aws-sdk-go/service/dynamodb -op "Query:*dynamodb.QueryInput:*dynamodb.QueryOutput"
+//
+// Note: this repo's EC2Client exposes every operation through a single
+// generic Do(action, method, path string, req, resp interface{})
+// method rather than one Go method per EC2 operation, so it has no
+// per-operation method set for awsmockgen to introspect directly. The
+// -op flag is how a generated per-operation client is described
+// instead; ec2er.go uses it against a vendored service/ec2.EC2 client
+// (mirroring service/dynamodb.DynamoDB) to produce EC2API the same
+// way dynamodber.go produces DynamoDBAPI.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+type operation struct {
+	Name   string
+	Input  string
+	Output string
+}
+
+type opsFlag []operation
+
+func (o *opsFlag) String() string { return fmt.Sprint([]operation(*o)) }
+
+func (o *opsFlag) Set(v string) error {
+	parts := strings.SplitN(v, ":", 3)
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid -op %q, want Name:InputType:OutputType", v)
+	}
+	*o = append(*o, operation{Name: parts[0], Input: parts[1], Output: parts[2]})
+	return nil
+}
+
+type importsFlag []string
+
+func (i *importsFlag) String() string { return strings.Join(*i, ",") }
+
+func (i *importsFlag) Set(v string) error {
+	*i = append(*i, v)
+	return nil
+}
+
+func main() {
+	var (
+		pkgName  = flag.String("pkg", "mocks", "output package name")
+		typeName = flag.String("type", "", "generated interface name, e.g. EC2API")
+		mockName = flag.String("mock", "", "generated mock struct name, e.g. EC2Mock")
+		out      = flag.String("out", "", "output file path")
+		ops      opsFlag
+		imports  importsFlag
+	)
+	flag.Var(&ops, "op", "Name:InputType:OutputType, repeatable")
+	flag.Var(&imports, "import", "extra import path needed by -op types, repeatable")
+	flag.Parse()
+
+	if *typeName == "" || *mockName == "" || *out == "" || len(ops) == 0 {
+		log.Fatal("awsmockgen: -type, -mock, -out and at least one -op are required")
+	}
+
+	src, err := generate(*pkgName, *typeName, *mockName, ops, imports)
+	if err != nil {
+		log.Fatalf("awsmockgen: %v", err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatalf("awsmockgen: writing %s: %v", *out, err)
+	}
+}
+
+func generate(pkgName, typeName, mockName string, ops []operation, imports []string) ([]byte, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by awsmockgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkgName)
+
+	if len(imports) > 0 {
+		fmt.Fprintf(&b, "import (\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&b, "\t%q\n", imp)
+		}
+		fmt.Fprintf(&b, ")\n\n")
+	}
+
+	fmt.Fprintf(&b, "// %s is a mockable subset of the operations a real client exposes.\n", typeName)
+	fmt.Fprintf(&b, "type %s interface {\n", typeName)
+	for _, op := range ops {
+		fmt.Fprintf(&b, "\t%s(%s) (%s, error)\n", op.Name, op.Input, op.Output)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "// %s is a %s test double: On registers a stubbed return value\n", mockName, typeName)
+	fmt.Fprintf(&b, "// for a method, and every call is recorded in Calls for later assertions.\n")
+	fmt.Fprintf(&b, "type %s struct {\n\tstubs map[string][]%sStub\n\tCalls []%sCall\n}\n\n", mockName, mockName, mockName)
+
+	fmt.Fprintf(&b, "// %sCall records one invocation made against a %s.\n", mockName, mockName)
+	fmt.Fprintf(&b, "type %sCall struct {\n\tMethod string\n\tArgs   []interface{}\n}\n\n", mockName)
+
+	fmt.Fprintf(&b, "// %sStub is a queued return value for one %s method.\n", mockName, mockName)
+	fmt.Fprintf(&b, "type %sStub struct {\n\tReturns []interface{}\n}\n\n", mockName)
+
+	fmt.Fprintf(&b, "// On queues a stub for method; Return sets what it produces. Stubs\n")
+	fmt.Fprintf(&b, "// for a method are consumed in the order they were registered.\n")
+	fmt.Fprintf(&b, "func (m *%s) On(method string, args ...interface{}) *%sStub {\n", mockName, mockName)
+	fmt.Fprintf(&b, "\tif m.stubs == nil {\n\t\tm.stubs = make(map[string][]%sStub)\n\t}\n", mockName)
+	fmt.Fprintf(&b, "\tm.stubs[method] = append(m.stubs[method], %sStub{})\n", mockName)
+	fmt.Fprintf(&b, "\treturn &m.stubs[method][len(m.stubs[method])-1]\n}\n\n")
+
+	fmt.Fprintf(&b, "// Return sets the values a stub produces.\n")
+	fmt.Fprintf(&b, "func (s *%sStub) Return(values ...interface{}) { s.Returns = values }\n\n", mockName)
+
+	fmt.Fprintf(&b, "func (m *%s) call(method string, args ...interface{}) []interface{} {\n", mockName)
+	fmt.Fprintf(&b, "\tm.Calls = append(m.Calls, %sCall{Method: method, Args: args})\n", mockName)
+	fmt.Fprintf(&b, "\tstubs := m.stubs[method]\n")
+	fmt.Fprintf(&b, "\tif len(stubs) == 0 {\n\t\tpanic(\"mocks: no stub registered for \" + method)\n\t}\n")
+	fmt.Fprintf(&b, "\tstub := stubs[0]\n\tm.stubs[method] = stubs[1:]\n\treturn stub.Returns\n}\n\n")
+
+	for _, op := range ops {
+		fmt.Fprintf(&b, "func (m *%s) %s(input %s) (%s, error) {\n", mockName, op.Name, op.Input, op.Output)
+		fmt.Fprintf(&b, "\tret := m.call(%q, input)\n", op.Name)
+		fmt.Fprintf(&b, "\tout, _ := ret[0].(%s)\n", op.Output)
+		fmt.Fprintf(&b, "\terr, _ := ret[1].(error)\n")
+		fmt.Fprintf(&b, "\treturn out, err\n}\n\n")
+	}
+
+	fmt.Fprintf(&b, "var _ %s = (*%s)(nil)\n", typeName, mockName)
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateProducesInterfaceAndMock(t *testing.T) {
+	src, err := generate(
+		"mocks",
+		"DynamoDBAPI",
+		"DynamoDBMock",
+		[]operation{{Name: "Query", Input: "*dynamodb.QueryInput", Output: "*dynamodb.QueryOutput"}},
+		[]string{"github.com/awslabs/aws-sdk-go/service/dynamodb"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := string(src)
+	for _, want := range []string{
+		"package mocks",
+		`"github.com/awslabs/aws-sdk-go/service/dynamodb"`,
+		"type DynamoDBAPI interface {",
+		"Query(*dynamodb.QueryInput) (*dynamodb.QueryOutput, error)",
+		"type DynamoDBMock struct {",
+		"func (m *DynamoDBMock) On(method string, args ...interface{}) *DynamoDBMockStub {",
+		"func (m *DynamoDBMock) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {",
+		"var _ DynamoDBAPI = (*DynamoDBMock)(nil)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated output missing %q\n\ngot:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateRequiresValidOp(t *testing.T) {
+	var o opsFlag
+	if err := o.Set("NoColon"); err == nil {
+		t.Error("expected an error for an -op value with no colons")
+	}
+}
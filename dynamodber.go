@@ -4,6 +4,8 @@ import (
 	"github.com/awslabs/aws-sdk-go/service/dynamodb"
 )
 
+//go:generate go run ./cmd/awsmockgen -pkg mocks -type DynamoDBAPI -mock DynamoDBMock -out mocks/dynamodbapi_generated.go -import github.com/awslabs/aws-sdk-go/service/dynamodb -op "Query:*dynamodb.QueryInput:*dynamodb.QueryOutput"
+
 type DynamoDBer interface {
 	Query(*dynamodb.QueryInput) (*dynamodb.QueryOutput, error)
 }
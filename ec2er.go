@@ -0,0 +1,15 @@
+package app
+
+import (
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+)
+
+//go:generate go run ./cmd/awsmockgen -pkg mocks -type EC2API -mock EC2Mock -out mocks/ec2api_generated.go -import github.com/awslabs/aws-sdk-go/service/ec2 -op "DescribeInstances:*ec2.DescribeInstancesInput:*ec2.DescribeInstancesOutput" -op "RunInstances:*ec2.RunInstancesInput:*ec2.RunInstancesOutput" -op "TerminateInstances:*ec2.TerminateInstancesInput:*ec2.TerminateInstancesOutput"
+
+type EC2er interface {
+	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	RunInstances(*ec2.RunInstancesInput) (*ec2.RunInstancesOutput, error)
+	TerminateInstances(*ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error)
+}
+
+var _ EC2er = (*ec2.EC2)(nil)
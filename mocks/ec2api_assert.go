@@ -0,0 +1,8 @@
+package mocks
+
+import "github.com/awslabs/aws-sdk-go/service/ec2"
+
+// Compile-time check that the real EC2 client satisfies the
+// generated EC2API, the same guarantee dynamodber.go makes for
+// DynamoDBer against dynamodb.DynamoDB.
+var _ EC2API = (*ec2.EC2)(nil)